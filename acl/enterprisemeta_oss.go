@@ -74,6 +74,10 @@ func (m *EnterpriseMeta) InDefaultNamespace() bool {
 	return true
 }
 
+func (m *EnterpriseMeta) Scope() string {
+	return ""
+}
+
 func (m *EnterpriseMeta) Merge(_ *EnterpriseMeta) {
 	// do nothing
 }
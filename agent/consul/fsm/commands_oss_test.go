@@ -1416,7 +1416,7 @@ func TestFSM_ConfigEntry_StatusCAS(t *testing.T) {
 		entry.RaftIndex.ModifyIndex = 2
 		conditions := config.(*structs.APIGatewayConfigEntry).Status.Conditions
 		require.Len(t, conditions, 1)
-		require.Equal(t, "Foo", conditions[0].Status)
+		require.Equal(t, structs.ConditionStatus("Foo"), conditions[0].Status)
 	}
 
 	// attempt to change the status with a regular update and make sure it's ignored
@@ -1445,7 +1445,7 @@ func TestFSM_ConfigEntry_StatusCAS(t *testing.T) {
 		require.NoError(t, err)
 		conditions := config.(*structs.APIGatewayConfigEntry).Status.Conditions
 		require.Len(t, conditions, 1)
-		require.Equal(t, "Foo", conditions[0].Status)
+		require.Equal(t, structs.ConditionStatus("Foo"), conditions[0].Status)
 	}
 }
 
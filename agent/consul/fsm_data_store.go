@@ -1,11 +1,22 @@
 package consul
 
 import (
+	"github.com/hashicorp/go-multierror"
+
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/consul/fsm"
 	"github.com/hashicorp/consul/agent/structs"
 )
 
+const (
+	// statusConditionTypeAccepted is the type of the condition set by
+	// UpdateStatus when a controller fails to reconcile a config entry.
+	statusConditionTypeAccepted = "Accepted"
+	// statusConditionReasonInvalid is the reason of the condition set by
+	// UpdateStatus when a controller fails to reconcile a config entry.
+	statusConditionReasonInvalid = "Invalid"
+)
+
 // FSMDataStore implements the DataStore interface using the Consul server and finite state manager.
 type FSMDataStore struct {
 	server *Server
@@ -56,12 +67,22 @@ func (f *FSMDataStore) UpdateStatus(entry structs.ControlledConfigEntry, err err
 		//TODO additional status messages for success?
 		return nil
 	}
+	// Only flatten a bare multierror, so that any context added by wrapping
+	// one is kept in the message.
+	msgs := []string{err.Error()}
+	if merr, ok := err.(*multierror.Error); ok {
+		msgs = make([]string, 0, len(merr.Errors))
+		for _, err := range merr.Errors {
+			msgs = append(msgs, err.Error())
+		}
+	}
 	status := structs.Status{
 		Conditions: []structs.Condition{{
-
-			Status: err.Error() + ": Accepted == false",
-		},
-		},
+			Type:    statusConditionTypeAccepted,
+			Status:  structs.ConditionStatusFalse,
+			Reason:  statusConditionReasonInvalid,
+			Message: structs.JoinConditionMessages(msgs),
+		}},
 	}
 	entry.SetStatus(status)
 	return f.Update(entry)
@@ -0,0 +1,85 @@
+package consul
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestFSMDataStore_UpdateStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	store := NewFSMDataStore(s1, s1.fsm)
+	require.NoError(t, store.Update(&structs.TCPRouteConfigEntry{
+		Kind: structs.TCPRoute,
+		Name: "route",
+	}))
+
+	updateStatus := func(t *testing.T, err error) structs.Condition {
+		t.Helper()
+
+		entry, getErr := store.GetConfigEntry(structs.TCPRoute, "route", nil)
+		require.NoError(t, getErr)
+		require.NoError(t, store.UpdateStatus(entry.(*structs.TCPRouteConfigEntry), err))
+
+		entry, getErr = store.GetConfigEntry(structs.TCPRoute, "route", nil)
+		require.NoError(t, getErr)
+		status := entry.(*structs.TCPRouteConfigEntry).GetStatus()
+		require.NoError(t, status.Validate())
+		require.Len(t, status.Conditions, 1)
+		return status.Conditions[0]
+	}
+
+	t.Run("plain error", func(t *testing.T) {
+		condition := updateStatus(t, errors.New("route not bound"))
+		require.Equal(t, "Accepted", condition.Type)
+		require.Equal(t, structs.ConditionStatusFalse, condition.Status)
+		require.Equal(t, "Invalid", condition.Reason)
+		require.Equal(t, "route not bound", condition.Message)
+	})
+
+	t.Run("long error is truncated", func(t *testing.T) {
+		condition := updateStatus(t, errors.New(strings.Repeat("x", 2048)))
+		require.Equal(t, structs.JoinConditionMessages([]string{strings.Repeat("x", 2048)}), condition.Message)
+		require.Less(t, len(condition.Message), 2048)
+	})
+
+	t.Run("multierror is joined", func(t *testing.T) {
+		merr := multierror.Append(nil,
+			errors.New("listener not found"),
+			errors.New("listener not found"),
+			errors.New("protocol mismatch"),
+		)
+		condition := updateStatus(t, merr)
+		require.Equal(t, "listener not found; protocol mismatch", condition.Message)
+	})
+
+	t.Run("wrapped multierror keeps its context", func(t *testing.T) {
+		merr := multierror.Append(nil,
+			errors.New("listener not found"),
+			errors.New("protocol mismatch"),
+		)
+		condition := updateStatus(t, fmt.Errorf("binding failed: %w", merr))
+		require.True(t, strings.HasPrefix(condition.Message, "binding failed: "))
+		require.Contains(t, condition.Message, "listener not found")
+		require.Contains(t, condition.Message, "protocol mismatch")
+	})
+}
@@ -1,7 +1,17 @@
 package structs
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mitchellh/hashstructure"
 
 	"github.com/hashicorp/consul/acl"
 )
@@ -23,6 +33,29 @@ type ResourceReference struct {
 	acl.EnterpriseMeta
 }
 
+// IsSame returns whether two references point to the same resource,
+// comparing the enterprise partition and namespace as well.
+func (r ResourceReference) IsSame(o ResourceReference) bool {
+	return r.Kind == o.Kind &&
+		r.Name == o.Name &&
+		r.SectionName == o.SectionName &&
+		r.EnterpriseMeta.IsSame(&o.EnterpriseMeta)
+}
+
+// String returns a stable "Kind/Name[/SectionName]" form of the reference
+// suitable for logging and map keys. When the reference has an enterprise
+// scope it is prefixed with "partition/namespace/".
+func (r ResourceReference) String() string {
+	ref := r.Kind + "/" + r.Name
+	if r.SectionName != "" {
+		ref += "/" + r.SectionName
+	}
+	if scope := r.EnterpriseMeta.Scope(); scope != "" {
+		ref = scope + "/" + ref
+	}
+	return ref
+}
+
 // Status is used for propagating back asynchronously calculated
 // messages from control loops to a user
 type Status struct {
@@ -31,13 +64,56 @@ type Status struct {
 	Conditions []Condition
 }
 
+// ConditionStatus is the status of a Condition, one of True, False or Unknown.
+type ConditionStatus string
+
+const (
+	ConditionStatusTrue    ConditionStatus = "True"
+	ConditionStatusFalse   ConditionStatus = "False"
+	ConditionStatusUnknown ConditionStatus = "Unknown"
+)
+
+// checkConditionStatus returns an error if the given status is
+// not one of the known ConditionStatus values.
+func checkConditionStatus(status ConditionStatus) error {
+	switch status {
+	case ConditionStatusTrue:
+		return nil
+	case ConditionStatusFalse:
+		return nil
+	case ConditionStatusUnknown:
+		return nil
+	default:
+		return fmt.Errorf("unrecognized condition status: %q", status)
+	}
+}
+
+// HTTPValue returns the lowercase form of the ConditionStatus used
+// by the HTTP API.
+func (c ConditionStatus) HTTPValue() string {
+	return strings.ToLower(string(c))
+}
+
+// ParseConditionStatusHTTP parses the HTTP API form of a ConditionStatus
+// as returned by HTTPValue.
+func ParseConditionStatusHTTP(value string) (ConditionStatus, error) {
+	for _, status := range []ConditionStatus{ConditionStatusTrue, ConditionStatusFalse, ConditionStatusUnknown} {
+		if status.HTTPValue() == value {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid condition status %q", value)
+}
+
 // Condition is used for a single message and state associated
 // with an object. For example, a ConfigEntry that references
 // multiple other resources may have different statuses with
 // respect to each of those resources.
 type Condition struct {
+	// Type is a value from a bounded set of types that an object might have
+	Type string
 	// Status is a value from a bounded set of statuses that an object might have
-	Status string
+	Status ConditionStatus
 	// Reason is a value from a bounded set of reasons for a given status
 	Reason string
 	// Message is a message that gives more detailed information about
@@ -48,4 +124,944 @@ type Condition struct {
 	Resource *ResourceReference
 	// LastTransitionTime is the time at which this Condition was created
 	LastTransitionTime *time.Time
+	// ObservedGeneration is the ModifyIndex of the ConfigEntry that this
+	// Condition was computed from, and can be compared against the entry's
+	// current ModifyIndex to tell whether the Condition is up to date
+	ObservedGeneration int64 `json:",omitempty"`
+	// TransitionCount is the number of times the Status of this Condition
+	// has changed, and can be used to detect flapping conditions
+	TransitionCount uint `json:",omitempty"`
+	// Details is an optional set of machine-readable key/value pairs
+	// that give additional context about the Condition
+	Details map[string]string `json:",omitempty"`
+	// ControlledBy optionally identifies the controller that set
+	// this Condition
+	ControlledBy string `json:",omitempty"`
+}
+
+// MarshalJSON emits LastTransitionTime in RFC3339 form with seconds
+// granularity, and omits it and Resource when they are unset.
+func (c Condition) MarshalJSON() ([]byte, error) {
+	type Alias Condition
+	exported := &struct {
+		Resource           *ResourceReference `json:",omitempty"`
+		LastTransitionTime string             `json:",omitempty"`
+		*Alias
+	}{
+		Resource: c.Resource,
+		Alias:    (*Alias)(&c),
+	}
+	if c.LastTransitionTime != nil {
+		exported.LastTransitionTime = c.LastTransitionTime.Format(time.RFC3339)
+	}
+
+	return json.Marshal(exported)
+}
+
+// maxConditionClockSkew is how far in the future a Condition's
+// LastTransitionTime may be before it is considered invalid.
+const maxConditionClockSkew = time.Minute
+
+// Validate checks that the Condition is well-formed.
+func (c Condition) Validate() error {
+	return c.ValidateAt(time.Now())
+}
+
+// ValidateAt checks that the Condition is well-formed, using now as the
+// current time when checking that LastTransitionTime is not in the future.
+func (c Condition) ValidateAt(now time.Time) error {
+	var err error
+	if c.LastTransitionTime != nil && c.LastTransitionTime.After(now.Add(maxConditionClockSkew)) {
+		err = multierror.Append(err, fmt.Errorf(
+			"LastTransitionTime %s is in the future", c.LastTransitionTime.Format(time.RFC3339)))
+	}
+	if len(c.Details) > metaMaxKeyPairs {
+		err = multierror.Append(err, fmt.Errorf(
+			"Details exceeds maximum element count %d", metaMaxKeyPairs))
+	}
+	for k, v := range c.Details {
+		if len(k) > metaKeyMaxLength {
+			err = multierror.Append(err, fmt.Errorf(
+				"Details key %q exceeds maximum length %d", k, metaKeyMaxLength))
+		}
+		if len(v) > metaValueMaxLength {
+			err = multierror.Append(err, fmt.Errorf(
+				"Details value for key %q exceeds maximum length %d", k, metaValueMaxLength))
+		}
+	}
+	return err
+}
+
+// ReasonKnown returns whether the Condition's Reason is allowed for its
+// Type and Status by any of the gateway or listener condition tables.
+func (c Condition) ReasonKnown() bool {
+	if checkGatewayConditionReason(GatewayConditionType(c.Type), c.Status, GatewayConditionReason(c.Reason)) == nil {
+		return true
+	}
+	return checkListenerConditionReason(ListenerConditionType(c.Type), c.Status, ListenerConditionReason(c.Reason)) == nil
+}
+
+// DumpConditionRegistry returns every condition type known to the gateway
+// and listener condition tables, with the reasons allowed for each status.
+// Types that appear in both tables have their reasons merged. The result is
+// a copy and can be modified freely.
+func DumpConditionRegistry() map[string]map[ConditionStatus][]string {
+	registry := make(map[string]map[ConditionStatus][]string)
+	add := func(name string, status ConditionStatus, reason string) {
+		statuses, ok := registry[name]
+		if !ok {
+			statuses = make(map[ConditionStatus][]string)
+			registry[name] = statuses
+		}
+		for _, r := range statuses[status] {
+			if r == reason {
+				return
+			}
+		}
+		statuses[status] = append(statuses[status], reason)
+	}
+
+	for name, statuses := range gatewayConditionReasons {
+		for status, reasons := range statuses {
+			for _, reason := range reasons {
+				add(string(name), status, string(reason))
+			}
+		}
+	}
+	for name, statuses := range listenerConditionReasons {
+		for status, reasons := range statuses {
+			for _, reason := range reasons {
+				add(string(name), status, string(reason))
+			}
+		}
+	}
+	return registry
+}
+
+// Validate checks that every condition in the Status is well-formed,
+// returning an error that lists each offending condition by index.
+func (s *Status) Validate() error {
+	return s.ValidateAt(time.Now())
+}
+
+// ValidateAt is like Validate but uses now as the current time when
+// checking each condition's LastTransitionTime.
+func (s *Status) ValidateAt(now time.Time) error {
+	var err error
+	for i, condition := range s.Conditions {
+		if condition.Type == "" {
+			err = multierror.Append(err, fmt.Errorf("Conditions[%d].Type is required", i))
+		}
+		if condition.Reason == "" {
+			err = multierror.Append(err, fmt.Errorf("Conditions[%d].Reason is required", i))
+		}
+		if statusErr := checkConditionStatus(condition.Status); statusErr != nil {
+			err = multierror.Append(err, fmt.Errorf("Conditions[%d]: %v", i, statusErr))
+		}
+		if conditionErr := condition.ValidateAt(now); conditionErr != nil {
+			if merr, ok := conditionErr.(*multierror.Error); ok {
+				for _, e := range merr.Errors {
+					err = multierror.Append(err, fmt.Errorf("Conditions[%d]: %v", i, e))
+				}
+			} else {
+				err = multierror.Append(err, fmt.Errorf("Conditions[%d]: %v", i, conditionErr))
+			}
+		}
+	}
+	return err
+}
+
+// Unreconciled returns true if no conditions have been set on the
+// Status, meaning a controller has not yet reconciled the ConfigEntry.
+func (s *Status) Unreconciled() bool {
+	return len(s.Conditions) == 0
+}
+
+// CountByStatus returns the number of conditions in the Status
+// with each ConditionStatus.
+func (s *Status) CountByStatus() map[ConditionStatus]int {
+	counts := make(map[ConditionStatus]int)
+	for _, condition := range s.Conditions {
+		counts[condition.Status]++
+	}
+	return counts
+}
+
+// AggregateStatus rolls the conditions with the given types up into a
+// single ConditionStatus. It returns False if any of them is False,
+// otherwise Unknown if any of them is Unknown or missing, otherwise True.
+func (s *Status) AggregateStatus(types []string) ConditionStatus {
+	aggregate := ConditionStatusTrue
+	for _, conditionType := range types {
+		found := false
+		for _, condition := range s.Conditions {
+			if condition.Type != conditionType {
+				continue
+			}
+			found = true
+			switch condition.Status {
+			case ConditionStatusTrue:
+			case ConditionStatusFalse:
+				return ConditionStatusFalse
+			default:
+				aggregate = ConditionStatusUnknown
+			}
+		}
+		if !found {
+			aggregate = ConditionStatusUnknown
+		}
+	}
+	return aggregate
+}
+
+// MissingConditionTypes returns the types in required that have no
+// corresponding condition in the Status.
+func (s *Status) MissingConditionTypes(required []string) []string {
+	present := make(map[string]struct{}, len(s.Conditions))
+	for _, condition := range s.Conditions {
+		present[condition.Type] = struct{}{}
+	}
+
+	var missing []string
+	for _, conditionType := range required {
+		if _, ok := present[conditionType]; !ok {
+			missing = append(missing, conditionType)
+		}
+	}
+	return missing
+}
+
+// ConditionsForKind returns the conditions in the Status that
+// reference a resource of the given kind.
+func (s *Status) ConditionsForKind(kind string) []Condition {
+	var conditions []Condition
+	for _, condition := range s.Conditions {
+		if condition.Resource != nil && condition.Resource.Kind == kind {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// ConditionConflict describes a condition that was present in both the
+// existing and the desired Status with a different ConditionStatus.
+type ConditionConflict struct {
+	Existing Condition
+	Desired  Condition
+}
+
+// GetCondition returns the condition with the given type and resource, and
+// whether it was found. A nil reference only matches conditions with no Resource.
+func (s *Status) GetCondition(conditionType string, ref *ResourceReference) (Condition, bool) {
+	i := s.conditionIndex(conditionType, ref)
+	if i < 0 {
+		return Condition{}, false
+	}
+	return s.Conditions[i], true
+}
+
+// SetCondition upserts the condition into the Status, replacing any existing
+// condition with the same Type and Resource. If the existing condition has the
+// same Status, Reason and Message its LastTransitionTime is kept so that no-op
+// reconciles don't churn it, and if its Status differs the TransitionCount is
+// incremented.
+func (s *Status) SetCondition(condition Condition) {
+	i := s.conditionIndex(condition.Type, condition.Resource)
+	if i < 0 {
+		s.Conditions = append(s.Conditions, condition)
+		return
+	}
+
+	existing := s.Conditions[i]
+	condition.TransitionCount = existing.TransitionCount
+	if existing.Status != condition.Status {
+		condition.TransitionCount++
+	} else if existing.Reason == condition.Reason && existing.Message == condition.Message {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+	s.Conditions[i] = condition
+}
+
+// RemoveConditionsFor removes every condition whose Resource matches
+// the given reference. It is a no-op if no conditions match. The remaining
+// conditions are copied to a new slice so that any shallow copies of the
+// Status are left untouched.
+func (s *Status) RemoveConditionsFor(ref *ResourceReference) {
+	var conditions []Condition
+	removed := false
+	for _, condition := range s.Conditions {
+		if sameResourceReference(condition.Resource, ref) {
+			removed = true
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+	if removed {
+		s.Conditions = conditions
+	}
+}
+
+// MergeDetectConflicts merges the conditions from desired into the Status
+// using SetCondition. It returns the conditions whose status changed as a
+// result of the merge.
+func (s *Status) MergeDetectConflicts(desired *Status) []ConditionConflict {
+	var conflicts []ConditionConflict
+	for _, condition := range desired.Conditions {
+		if i := s.conditionIndex(condition.Type, condition.Resource); i >= 0 && s.Conditions[i].Status != condition.Status {
+			conflicts = append(conflicts, ConditionConflict{
+				Existing: s.Conditions[i],
+				Desired:  condition,
+			})
+		}
+		s.SetCondition(condition)
+	}
+	return conflicts
+}
+
+// SortConditions sorts the conditions by Type, then by Resource,
+// then by Reason, so that they can be rendered deterministically.
+func SortConditions(conds []Condition) {
+	resourceKey := func(c Condition) string {
+		if c.Resource == nil {
+			return ""
+		}
+		return c.Resource.String()
+	}
+
+	sort.SliceStable(conds, func(i, j int) bool {
+		a, b := conds[i], conds[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if aKey, bKey := resourceKey(a), resourceKey(b); aKey != bKey {
+			return aKey < bKey
+		}
+		return a.Reason < b.Reason
+	})
+}
+
+// ConditionsEqual returns whether the two sets of conditions are equal,
+// ignoring their order, LastTransitionTime and TransitionCount, and
+// treating empty Details as unset. Neither slice is modified.
+func ConditionsEqual(a, b []Condition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	normalize := func(conds []Condition) []Condition {
+		normalized := make([]Condition, len(conds))
+		for i, condition := range conds {
+			condition.LastTransitionTime = nil
+			condition.TransitionCount = 0
+			if len(condition.Details) == 0 {
+				condition.Details = nil
+			}
+			normalized[i] = condition
+		}
+		SortConditions(normalized)
+		return normalized
+	}
+
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// conditionIndex returns the index of the condition with the given type
+// and resource, or -1 if there is none.
+func (s *Status) conditionIndex(conditionType string, ref *ResourceReference) int {
+	for i, condition := range s.Conditions {
+		if condition.Type == conditionType && sameResourceReference(condition.Resource, ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameResourceReference(a, b *ResourceReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.IsSame(*b)
+}
+
+// maxConditionMessageLength is the maximum length of a message
+// built by JoinConditionMessages.
+const maxConditionMessageLength = 1024
+
+// JoinConditionMessages joins the given messages into a single Condition
+// message, dropping empty and duplicate messages. If the result is longer than
+// maxConditionMessageLength it is truncated and suffixed with "...".
+func JoinConditionMessages(msgs []string) string {
+	seen := make(map[string]struct{}, len(msgs))
+	unique := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		if _, ok := seen[msg]; ok || msg == "" {
+			continue
+		}
+		seen[msg] = struct{}{}
+		unique = append(unique, msg)
+	}
+
+	message := strings.Join(unique, "; ")
+	if len(message) <= maxConditionMessageLength {
+		return message
+	}
+
+	const ellipsis = "..."
+	cut := maxConditionMessageLength - len(ellipsis)
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + ellipsis
+}
+
+// Clone returns a copy of the Condition that shares no memory
+// with the original.
+func (c Condition) Clone() Condition {
+	if c.Resource != nil {
+		resource := *c.Resource
+		c.Resource = &resource
+	}
+	if c.LastTransitionTime != nil {
+		transitionTime := *c.LastTransitionTime
+		c.LastTransitionTime = &transitionTime
+	}
+	if c.Details != nil {
+		details := make(map[string]string, len(c.Details))
+		for k, v := range c.Details {
+			details[k] = v
+		}
+		c.Details = details
+	}
+	return c
+}
+
+// AsError returns an error listing every condition in the Status that is
+// False, or nil if there are none.
+func (s *Status) AsError() error {
+	var err error
+	for _, condition := range s.Conditions {
+		if condition.Status != ConditionStatusFalse {
+			continue
+		}
+		err = multierror.Append(err, fmt.Errorf("condition %q is False with reason %q: %s", condition.Type, condition.Reason, condition.Message))
+	}
+	return err
+}
+
+// Digest returns a hash of the conditions in the Status that can be
+// stored to cheaply detect meaningful changes. The order of the conditions,
+// their LastTransitionTime and their TransitionCount do not affect the
+// digest. An empty string is returned if the conditions cannot be hashed.
+func (s *Status) Digest() string {
+	conditions := make([]Condition, 0, len(s.Conditions))
+	for _, condition := range s.Conditions {
+		condition.LastTransitionTime = nil
+		condition.TransitionCount = 0
+		conditions = append(conditions, condition)
+	}
+
+	v, err := hashstructure.Hash(struct {
+		Conditions []Condition `hash:"set"`
+	}{
+		Conditions: conditions,
+	}, nil)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatUint(v, 16)
+}
+
+// K8sCondition mirrors the shape of the Kubernetes meta/v1 Condition type
+// used by Gateway API objects, so that conditions can be synced back to
+// Kubernetes without depending on the Kubernetes API packages.
+type K8sCondition struct {
+	Type               string          `json:"type"`
+	Status             ConditionStatus `json:"status"`
+	ObservedGeneration int64           `json:"observedGeneration,omitempty"`
+	LastTransitionTime time.Time       `json:"lastTransitionTime"`
+	Reason             string          `json:"reason"`
+	Message            string          `json:"message"`
+}
+
+// ToK8sConditions returns the conditions of the Status as a Kubernetes
+// condition list. Kubernetes only allows a single condition of each type,
+// so the first condition of a given type wins and conditions without a
+// type are skipped. Resource references have no Kubernetes equivalent
+// and are dropped.
+func (s *Status) ToK8sConditions() []K8sCondition {
+	var (
+		conditions []K8sCondition
+		seen       = make(map[string]struct{})
+	)
+	for _, condition := range s.Conditions {
+		if condition.Type == "" {
+			continue
+		}
+		if _, ok := seen[condition.Type]; ok {
+			continue
+		}
+		seen[condition.Type] = struct{}{}
+
+		k8sCondition := K8sCondition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			ObservedGeneration: condition.ObservedGeneration,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		}
+		if condition.LastTransitionTime != nil {
+			k8sCondition.LastTransitionTime = *condition.LastTransitionTime
+		}
+		conditions = append(conditions, k8sCondition)
+	}
+	return conditions
+}
+
+// ConditionFromK8s converts a Kubernetes condition back into a Condition.
+// A zero LastTransitionTime is treated as unset.
+func ConditionFromK8s(k8sCondition K8sCondition) Condition {
+	condition := Condition{
+		Type:               k8sCondition.Type,
+		Status:             k8sCondition.Status,
+		ObservedGeneration: k8sCondition.ObservedGeneration,
+		Reason:             k8sCondition.Reason,
+		Message:            k8sCondition.Message,
+	}
+	if !k8sCondition.LastTransitionTime.IsZero() {
+		lastTransitionTime := k8sCondition.LastTransitionTime
+		condition.LastTransitionTime = &lastTransitionTime
+	}
+	return condition
+}
+
+// ConditionOption sets an optional field on a Condition built by one of
+// the condition constructors, such as NewGatewayCondition.
+type ConditionOption func(*Condition)
+
+// WithControlledBy records the controller that set the Condition.
+func WithControlledBy(controller string) ConditionOption {
+	return func(c *Condition) {
+		c.ControlledBy = controller
+	}
+}
+
+// GatewayConditionType is a type of condition associated with a
+// gateway, set on the Status of an APIGatewayConfigEntry.
+type GatewayConditionType string
+
+// GatewayConditionReason defines the set of reasons that explain why a
+// particular gateway condition type has been raised.
+type GatewayConditionReason string
+
+const (
+	// This condition indicates whether the gateway has been accepted by
+	// the controller.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Accepted"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "InvalidCertificates"
+	// * "ListenersNotValid"
+	// * "UnsupportedAddress"
+	//
+	// Possible reasons for this condition to be Unknown are:
+	//
+	// * "Pending"
+	GatewayConditionAccepted GatewayConditionType = "Accepted"
+
+	// This reason is used with the "Accepted" condition when the condition
+	// is True.
+	GatewayReasonAccepted GatewayConditionReason = "Accepted"
+
+	// This reason is used with the "Accepted" and "ResolvedRefs" conditions
+	// when the gateway has a listener whose certificate references are
+	// invalid or cannot be found.
+	GatewayReasonInvalidCertificates GatewayConditionReason = "InvalidCertificates"
+
+	// This reason is used with the "Accepted" condition when none of the
+	// gateway's listeners are valid.
+	GatewayReasonListenersNotValid GatewayConditionReason = "ListenersNotValid"
+
+	// This reason is used with the "Accepted" condition when the gateway
+	// requests an address type that is not supported.
+	GatewayReasonUnsupportedAddress GatewayConditionReason = "UnsupportedAddress"
+
+	// This reason is used with the "Accepted" condition when the gateway
+	// has not yet been reconciled by the controller.
+	GatewayReasonPending GatewayConditionReason = "Pending"
+)
+
+const (
+	// This condition indicates that the gateway has routes bound to it
+	// that conflict with one another.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "RouteConflict"
+	// * "CrossGatewayConflict"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "NoConflict"
+	GatewayConditionConflicted GatewayConditionType = "Conflicted"
+
+	// This reason is used with the "Conflicted" condition when the condition
+	// is True.
+	GatewayReasonRouteConflict GatewayConditionReason = "RouteConflict"
+
+	// This reason is used with the "Conflicted" condition when the gateway
+	// binds a hostname and port that another gateway already binds.
+	GatewayReasonCrossGatewayConflict GatewayConditionReason = "CrossGatewayConflict"
+
+	// This reason is used with the "Conflicted" condition when the condition
+	// is False.
+	GatewayReasonNoConflict GatewayConditionReason = "NoConflict"
+)
+
+const (
+	// This condition indicates whether the controller was able to resolve
+	// all of the references made by the gateway.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "ResolvedRefs"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "InvalidCertificates"
+	GatewayConditionResolvedRefs GatewayConditionType = "ResolvedRefs"
+
+	// This reason is used with the "ResolvedRefs" condition when the
+	// condition is True.
+	GatewayReasonResolvedRefs GatewayConditionReason = "ResolvedRefs"
+)
+
+const (
+	// This condition indicates that the gateway is serving traffic, but
+	// only on a subset of its listeners because the others are invalid.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "SomeListenersInvalid"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "AllListenersValid"
+	GatewayConditionDegraded GatewayConditionType = "Degraded"
+
+	// This reason is used with the "Degraded" condition when the condition
+	// is True.
+	GatewayReasonSomeListenersInvalid GatewayConditionReason = "SomeListenersInvalid"
+
+	// This reason is used with the "Degraded" condition when the condition
+	// is False.
+	GatewayReasonAllListenersValid GatewayConditionReason = "AllListenersValid"
+)
+
+// gatewayConditionReasons is the set of reasons that are allowed for
+// each gateway condition type and status.
+var gatewayConditionReasons = map[GatewayConditionType]map[ConditionStatus][]GatewayConditionReason{
+	GatewayConditionAccepted: {
+		ConditionStatusTrue: {
+			GatewayReasonAccepted,
+		},
+		ConditionStatusFalse: {
+			GatewayReasonInvalidCertificates,
+			GatewayReasonListenersNotValid,
+			GatewayReasonUnsupportedAddress,
+		},
+		ConditionStatusUnknown: {
+			GatewayReasonPending,
+		},
+	},
+	GatewayConditionConflicted: {
+		ConditionStatusTrue: {
+			GatewayReasonRouteConflict,
+			GatewayReasonCrossGatewayConflict,
+		},
+		ConditionStatusFalse: {
+			GatewayReasonNoConflict,
+		},
+		ConditionStatusUnknown: {},
+	},
+	GatewayConditionResolvedRefs: {
+		ConditionStatusTrue: {
+			GatewayReasonResolvedRefs,
+		},
+		ConditionStatusFalse: {
+			GatewayReasonInvalidCertificates,
+		},
+		ConditionStatusUnknown: {},
+	},
+	GatewayConditionDegraded: {
+		ConditionStatusTrue: {
+			GatewayReasonSomeListenersInvalid,
+		},
+		ConditionStatusFalse: {
+			GatewayReasonAllListenersValid,
+		},
+		ConditionStatusUnknown: {},
+	},
+}
+
+// NewGatewayCondition is a helper to build allowable Conditions for a
+// gateway. It returns an error if the reason is not allowed for the
+// given condition type and status.
+func NewGatewayCondition(name GatewayConditionType, status ConditionStatus, reason GatewayConditionReason, message string, resource *ResourceReference, opts ...ConditionOption) (Condition, error) {
+	if err := checkGatewayConditionReason(name, status, reason); err != nil {
+		return Condition{}, err
+	}
+
+	now := time.Now().UTC()
+	condition := Condition{
+		Type:               string(name),
+		Status:             status,
+		Reason:             string(reason),
+		Message:            message,
+		Resource:           resource,
+		LastTransitionTime: &now,
+	}
+	for _, opt := range opts {
+		opt(&condition)
+	}
+	return condition, nil
+}
+
+func checkGatewayConditionReason(name GatewayConditionType, status ConditionStatus, reason GatewayConditionReason) error {
+	if err := checkConditionStatus(status); err != nil {
+		return err
+	}
+
+	reasons, ok := gatewayConditionReasons[name]
+	if !ok {
+		return fmt.Errorf("unrecognized gateway condition type: %q", name)
+	}
+
+	for _, r := range reasons[status] {
+		if r == reason {
+			return nil
+		}
+	}
+	return fmt.Errorf("gateway condition reason %q not allowed for gateway condition type %q with status %q", reason, name, status)
+}
+
+// ListenerConditionType is a type of condition associated with a single
+// gateway listener. Listener conditions reference the gateway with the
+// listener's name set as the SectionName.
+type ListenerConditionType string
+
+// ListenerConditionReason defines the set of reasons that explain why a
+// particular listener condition type has been raised.
+type ListenerConditionReason string
+
+const (
+	// This condition indicates whether the listener has been accepted by
+	// the controller.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Accepted"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "PortUnavailable"
+	// * "UnsupportedProtocol"
+	//
+	// Possible reasons for this condition to be Unknown are:
+	//
+	// * "Pending"
+	ListenerConditionAccepted ListenerConditionType = "Accepted"
+
+	// This reason is used with the "Accepted" condition when the condition
+	// is True.
+	ListenerReasonAccepted ListenerConditionReason = "Accepted"
+
+	// This reason is used with the "Accepted" condition when the listener
+	// requests a port that cannot be used.
+	ListenerReasonPortUnavailable ListenerConditionReason = "PortUnavailable"
+
+	// This reason is used with the "Accepted" condition when the listener
+	// uses a protocol that is not supported.
+	ListenerReasonUnsupportedProtocol ListenerConditionReason = "UnsupportedProtocol"
+
+	// This reason is used with the "Accepted" and "Programmed" conditions
+	// when the listener has not yet been reconciled.
+	ListenerReasonPending ListenerConditionReason = "Pending"
+)
+
+const (
+	// This condition indicates that the listener conflicts with another
+	// listener on the same gateway.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "HostnameConflict"
+	// * "ProtocolConflict"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "NoConflicts"
+	ListenerConditionConflicted ListenerConditionType = "Conflicted"
+
+	// This reason is used with the "Conflicted" condition when the listener
+	// shares a port and hostname with another listener.
+	ListenerReasonHostnameConflict ListenerConditionReason = "HostnameConflict"
+
+	// This reason is used with the "Conflicted" condition when the listener
+	// shares a port with another listener using a different protocol.
+	ListenerReasonProtocolConflict ListenerConditionReason = "ProtocolConflict"
+
+	// This reason is used with the "Conflicted" condition when the condition
+	// is False.
+	ListenerReasonNoConflicts ListenerConditionReason = "NoConflicts"
+)
+
+const (
+	// This condition indicates whether the controller was able to resolve
+	// all of the references made by the listener.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "ResolvedRefs"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "InvalidCertificateRef"
+	// * "InvalidRouteKinds"
+	ListenerConditionResolvedRefs ListenerConditionType = "ResolvedRefs"
+
+	// This reason is used with the "ResolvedRefs" condition when the
+	// condition is True.
+	ListenerReasonResolvedRefs ListenerConditionReason = "ResolvedRefs"
+
+	// This reason is used with the "ResolvedRefs" condition when a
+	// certificate referenced by the listener is invalid or does not exist.
+	ListenerReasonInvalidCertificateRef ListenerConditionReason = "InvalidCertificateRef"
+
+	// This reason is used with the "ResolvedRefs" condition when the
+	// listener allows a route kind that is not supported.
+	ListenerReasonInvalidRouteKinds ListenerConditionReason = "InvalidRouteKinds"
+)
+
+const (
+	// This condition indicates whether the listener has been programmed
+	// into the data plane.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Programmed"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "Invalid"
+	//
+	// Possible reasons for this condition to be Unknown are:
+	//
+	// * "Pending"
+	ListenerConditionProgrammed ListenerConditionType = "Programmed"
+
+	// This reason is used with the "Programmed" condition when the
+	// condition is True.
+	ListenerReasonProgrammed ListenerConditionReason = "Programmed"
+
+	// This reason is used with the "Programmed" condition when the listener
+	// is invalid and cannot be programmed.
+	ListenerReasonInvalid ListenerConditionReason = "Invalid"
+)
+
+// listenerConditionReasons is the set of reasons that are allowed for
+// each listener condition type and status.
+var listenerConditionReasons = map[ListenerConditionType]map[ConditionStatus][]ListenerConditionReason{
+	ListenerConditionAccepted: {
+		ConditionStatusTrue: {
+			ListenerReasonAccepted,
+		},
+		ConditionStatusFalse: {
+			ListenerReasonPortUnavailable,
+			ListenerReasonUnsupportedProtocol,
+		},
+		ConditionStatusUnknown: {
+			ListenerReasonPending,
+		},
+	},
+	ListenerConditionConflicted: {
+		ConditionStatusTrue: {
+			ListenerReasonHostnameConflict,
+			ListenerReasonProtocolConflict,
+		},
+		ConditionStatusFalse: {
+			ListenerReasonNoConflicts,
+		},
+		ConditionStatusUnknown: {},
+	},
+	ListenerConditionResolvedRefs: {
+		ConditionStatusTrue: {
+			ListenerReasonResolvedRefs,
+		},
+		ConditionStatusFalse: {
+			ListenerReasonInvalidCertificateRef,
+			ListenerReasonInvalidRouteKinds,
+		},
+		ConditionStatusUnknown: {},
+	},
+	ListenerConditionProgrammed: {
+		ConditionStatusTrue: {
+			ListenerReasonProgrammed,
+		},
+		ConditionStatusFalse: {
+			ListenerReasonInvalid,
+		},
+		ConditionStatusUnknown: {
+			ListenerReasonPending,
+		},
+	},
+}
+
+// NewListenerCondition is a helper to build allowable Conditions for a
+// gateway listener. The resource must reference the gateway with the
+// listener's name as its SectionName. It returns an error if the resource
+// is missing or if the reason is not allowed for the given condition type
+// and status.
+func NewListenerCondition(name ListenerConditionType, status ConditionStatus, reason ListenerConditionReason, message string, resource *ResourceReference, opts ...ConditionOption) (Condition, error) {
+	if resource == nil || resource.SectionName == "" {
+		return Condition{}, fmt.Errorf("listener condition %q requires a resource with a SectionName", name)
+	}
+	if err := checkListenerConditionReason(name, status, reason); err != nil {
+		return Condition{}, err
+	}
+
+	now := time.Now().UTC()
+	condition := Condition{
+		Type:               string(name),
+		Status:             status,
+		Reason:             string(reason),
+		Message:            message,
+		Resource:           resource,
+		LastTransitionTime: &now,
+	}
+	for _, opt := range opts {
+		opt(&condition)
+	}
+	return condition, nil
+}
+
+func checkListenerConditionReason(name ListenerConditionType, status ConditionStatus, reason ListenerConditionReason) error {
+	if err := checkConditionStatus(status); err != nil {
+		return err
+	}
+
+	reasons, ok := listenerConditionReasons[name]
+	if !ok {
+		return fmt.Errorf("unrecognized listener condition type: %q", name)
+	}
+
+	for _, r := range reasons[status] {
+		if r == reason {
+			return nil
+		}
+	}
+	return fmt.Errorf("listener condition reason %q not allowed for listener condition type %q with status %q", reason, name, status)
 }
@@ -0,0 +1,1187 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceReference_IsSame(t *testing.T) {
+	ref := ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}
+
+	require.True(t, ref.IsSame(ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}))
+	require.False(t, ref.IsSame(ResourceReference{Kind: TCPRoute, Name: "gateway", SectionName: "listener"}))
+	require.False(t, ref.IsSame(ResourceReference{Kind: APIGateway, Name: "other", SectionName: "listener"}))
+	require.False(t, ref.IsSame(ResourceReference{Kind: APIGateway, Name: "gateway"}))
+	require.True(t, ResourceReference{}.IsSame(ResourceReference{}))
+}
+
+func TestResourceReference_String(t *testing.T) {
+	require.Equal(t, "api-gateway/gateway", ResourceReference{Kind: APIGateway, Name: "gateway"}.String())
+	require.Equal(t, "api-gateway/gateway/listener", ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}.String())
+	require.Equal(t, "/", ResourceReference{}.String())
+}
+
+func TestCheckConditionStatus(t *testing.T) {
+	cases := []struct {
+		status ConditionStatus
+		valid  bool
+	}{
+		{status: ConditionStatusTrue, valid: true},
+		{status: ConditionStatusFalse, valid: true},
+		{status: ConditionStatusUnknown, valid: true},
+		{status: "Maybe", valid: false},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			err := checkConditionStatus(tc.status)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, `unrecognized condition status: "Maybe"`)
+			}
+		})
+	}
+}
+
+func TestConditionStatus_HTTPValue(t *testing.T) {
+	cases := map[ConditionStatus]string{
+		ConditionStatusTrue:    "true",
+		ConditionStatusFalse:   "false",
+		ConditionStatusUnknown: "unknown",
+	}
+	for status, value := range cases {
+		require.Equal(t, value, status.HTTPValue())
+
+		parsed, err := ParseConditionStatusHTTP(value)
+		require.NoError(t, err)
+		require.Equal(t, status, parsed)
+	}
+
+	for _, value := range []string{"", "True", "yes"} {
+		_, err := ParseConditionStatusHTTP(value)
+		require.Error(t, err)
+	}
+}
+
+func TestStatus_DeepCopy(t *testing.T) {
+	now := time.Now()
+	original := &Status{
+		Conditions: []Condition{
+			{
+				Type:               "Accepted",
+				Status:             ConditionStatusTrue,
+				Resource:           &ResourceReference{Kind: APIGateway, Name: "gateway"},
+				LastTransitionTime: &now,
+				Details:            map[string]string{"listener_port": "8080"},
+			},
+		},
+	}
+
+	cp := original.DeepCopy()
+	require.Equal(t, original, cp)
+
+	later := now.Add(time.Hour)
+	cp.Conditions[0].Status = ConditionStatusFalse
+	cp.Conditions[0].Resource.Name = "other"
+	*cp.Conditions[0].LastTransitionTime = later
+	cp.Conditions[0].Details["listener_port"] = "9090"
+	cp.Conditions = append(cp.Conditions, Condition{Type: "ResolvedRefs"})
+
+	require.Len(t, original.Conditions, 1)
+	require.Equal(t, ConditionStatusTrue, original.Conditions[0].Status)
+	require.Equal(t, "gateway", original.Conditions[0].Resource.Name)
+	require.Equal(t, now, *original.Conditions[0].LastTransitionTime)
+	require.Equal(t, "8080", original.Conditions[0].Details["listener_port"])
+
+	require.Equal(t, &Status{}, (&Status{}).DeepCopy())
+}
+
+func TestStatus_Validate(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+
+	require.NoError(t, (&Status{}).Validate())
+
+	valid := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted"},
+			{Type: "ResolvedRefs", Status: ConditionStatusUnknown, Reason: "Pending"},
+		},
+	}
+	require.NoError(t, valid.Validate())
+
+	invalid := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted"},
+			{Status: ConditionStatusFalse, Reason: "Invalid"},
+			{Type: "ResolvedRefs", Status: "Maybe"},
+			{Type: "Programmed", Status: ConditionStatusTrue, Reason: "Programmed", LastTransitionTime: &future},
+		},
+	}
+	err := invalid.Validate()
+	require.Error(t, err)
+
+	merr, ok := err.(*multierror.Error)
+	require.True(t, ok)
+	require.Len(t, merr.Errors, 4)
+	require.EqualError(t, merr.Errors[0], "Conditions[1].Type is required")
+	require.EqualError(t, merr.Errors[1], "Conditions[2].Reason is required")
+	require.EqualError(t, merr.Errors[2], `Conditions[2]: unrecognized condition status: "Maybe"`)
+	require.ErrorContains(t, merr.Errors[3], "Conditions[3]: LastTransitionTime")
+
+	// the clock can be controlled by the caller
+	require.NoError(t, (&Status{Conditions: invalid.Conditions[3:]}).ValidateAt(future))
+}
+
+func TestStatus_Unreconciled(t *testing.T) {
+	require.True(t, (&Status{}).Unreconciled())
+	require.True(t, (&Status{Conditions: []Condition{}}).Unreconciled())
+	require.False(t, (&Status{Conditions: []Condition{{Status: ConditionStatusFalse}}}).Unreconciled())
+}
+
+func TestStatus_CountByStatus(t *testing.T) {
+	status := Status{
+		Conditions: []Condition{
+			{Status: ConditionStatusTrue},
+			{Status: ConditionStatusTrue},
+			{Status: ConditionStatusTrue},
+			{Status: ConditionStatusFalse},
+			{Status: ConditionStatusUnknown},
+			{Status: ConditionStatusUnknown},
+		},
+	}
+
+	require.Equal(t, map[ConditionStatus]int{
+		ConditionStatusTrue:    3,
+		ConditionStatusFalse:   1,
+		ConditionStatusUnknown: 2,
+	}, status.CountByStatus())
+
+	require.Empty(t, (&Status{}).CountByStatus())
+}
+
+func TestStatus_AggregateStatus(t *testing.T) {
+	status := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue},
+			{Type: "ResolvedRefs", Status: ConditionStatusTrue},
+			{Type: "Programmed", Status: ConditionStatusUnknown},
+			{Type: "Conflicted", Status: ConditionStatusFalse},
+		},
+	}
+
+	cases := map[string]struct {
+		types    []string
+		expected ConditionStatus
+	}{
+		"none":               {expected: ConditionStatusTrue},
+		"all true":           {types: []string{"Accepted", "ResolvedRefs"}, expected: ConditionStatusTrue},
+		"unknown over true":  {types: []string{"Accepted", "Programmed"}, expected: ConditionStatusUnknown},
+		"missing is unknown": {types: []string{"Accepted", "Missing"}, expected: ConditionStatusUnknown},
+		"false over unknown": {types: []string{"Programmed", "Conflicted"}, expected: ConditionStatusFalse},
+		"false over true":    {types: []string{"Conflicted", "Accepted"}, expected: ConditionStatusFalse},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, status.AggregateStatus(tc.types))
+		})
+	}
+}
+
+func TestStatus_MissingConditionTypes(t *testing.T) {
+	status := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue},
+			{Type: "ResolvedRefs", Status: ConditionStatusFalse},
+		},
+	}
+
+	require.Equal(t, []string{"Conflicted", "Programmed"},
+		status.MissingConditionTypes([]string{"Accepted", "Conflicted", "ResolvedRefs", "Programmed"}))
+	require.Empty(t, status.MissingConditionTypes([]string{"Accepted", "ResolvedRefs"}))
+	require.Empty(t, status.MissingConditionTypes(nil))
+	require.Equal(t, []string{"Accepted"}, (&Status{}).MissingConditionTypes([]string{"Accepted"}))
+}
+
+func TestStatus_ConditionsForKind(t *testing.T) {
+	route := Condition{
+		Status:   ConditionStatusTrue,
+		Resource: &ResourceReference{Kind: HTTPRoute, Name: "route"},
+	}
+	gateway := Condition{
+		Status:   ConditionStatusFalse,
+		Resource: &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"},
+	}
+	status := Status{
+		Conditions: []Condition{route, gateway, {Status: ConditionStatusUnknown}},
+	}
+
+	require.Equal(t, []Condition{route}, status.ConditionsForKind(HTTPRoute))
+	require.Equal(t, []Condition{gateway}, status.ConditionsForKind(APIGateway))
+	require.Empty(t, status.ConditionsForKind(TCPRoute))
+}
+
+func TestStatus_SetCondition(t *testing.T) {
+	earlier := time.Now().Add(-time.Hour)
+	now := time.Now()
+
+	gateway := &ResourceReference{Kind: APIGateway, Name: "gateway"}
+	listener := &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}
+
+	status := Status{}
+	status.SetCondition(Condition{Type: "Accepted", Status: ConditionStatusTrue, Resource: gateway, LastTransitionTime: &earlier})
+	status.SetCondition(Condition{Type: "Accepted", Status: ConditionStatusTrue, Resource: listener, LastTransitionTime: &earlier})
+	status.SetCondition(Condition{Type: "ResolvedRefs", Status: ConditionStatusTrue, LastTransitionTime: &earlier})
+	require.Len(t, status.Conditions, 3)
+
+	t.Run("no-op keeps transition time", func(t *testing.T) {
+		status.SetCondition(Condition{Type: "Accepted", Status: ConditionStatusTrue, Resource: gateway, LastTransitionTime: &now})
+		require.Len(t, status.Conditions, 3)
+		require.Equal(t, &earlier, status.Conditions[0].LastTransitionTime)
+	})
+
+	t.Run("nil resources match", func(t *testing.T) {
+		status.SetCondition(Condition{Type: "ResolvedRefs", Status: ConditionStatusTrue, LastTransitionTime: &now})
+		require.Len(t, status.Conditions, 3)
+		require.Equal(t, &earlier, status.Conditions[2].LastTransitionTime)
+	})
+
+	t.Run("changed message replaces", func(t *testing.T) {
+		status.SetCondition(Condition{Type: "Accepted", Status: ConditionStatusTrue, Message: "accepted", Resource: listener, LastTransitionTime: &now})
+		require.Len(t, status.Conditions, 3)
+		require.Equal(t, "accepted", status.Conditions[1].Message)
+		require.Equal(t, &now, status.Conditions[1].LastTransitionTime)
+	})
+
+	t.Run("changed status replaces", func(t *testing.T) {
+		status.SetCondition(Condition{Type: "Accepted", Status: ConditionStatusFalse, Resource: gateway, LastTransitionTime: &now})
+		require.Len(t, status.Conditions, 3)
+		require.Equal(t, ConditionStatusFalse, status.Conditions[0].Status)
+		require.Equal(t, &now, status.Conditions[0].LastTransitionTime)
+	})
+
+	t.Run("new type appends", func(t *testing.T) {
+		status.SetCondition(Condition{Type: "Conflicted", Status: ConditionStatusFalse, Resource: gateway})
+		require.Len(t, status.Conditions, 4)
+		require.Equal(t, "Conflicted", status.Conditions[3].Type)
+	})
+}
+
+func TestStatus_GetCondition(t *testing.T) {
+	gateway := &ResourceReference{Kind: APIGateway, Name: "gateway"}
+	listener := &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}
+
+	status := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue, Resource: gateway},
+			{Type: "Accepted", Status: ConditionStatusFalse, Resource: listener},
+			{Type: "ResolvedRefs", Status: ConditionStatusUnknown},
+		},
+	}
+
+	condition, found := status.GetCondition("Accepted", &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"})
+	require.True(t, found)
+	require.Equal(t, status.Conditions[1], condition)
+
+	condition, found = status.GetCondition("ResolvedRefs", nil)
+	require.True(t, found)
+	require.Equal(t, status.Conditions[2], condition)
+
+	_, found = status.GetCondition("Accepted", nil)
+	require.False(t, found)
+
+	_, found = status.GetCondition("ResolvedRefs", gateway)
+	require.False(t, found)
+
+	_, found = status.GetCondition("Conflicted", gateway)
+	require.False(t, found)
+}
+
+func TestStatus_RemoveConditionsFor(t *testing.T) {
+	foo := &ResourceReference{Kind: "service", Name: "foo"}
+	bar := &ResourceReference{Kind: "service", Name: "bar"}
+
+	status := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue},
+			{Type: "ResolvedRefs", Status: ConditionStatusTrue, Resource: foo},
+			{Type: "ResolvedRefs", Status: ConditionStatusFalse, Resource: bar},
+			{Type: "Programmed", Status: ConditionStatusTrue, Resource: foo},
+		},
+	}
+
+	status.RemoveConditionsFor(&ResourceReference{Kind: "service", Name: "baz"})
+	require.Len(t, status.Conditions, 4)
+
+	// shallow copies, such as an entry read from the state store, share the
+	// original backing array and must not be modified
+	shallow := status
+	original := append([]Condition(nil), status.Conditions...)
+
+	status.RemoveConditionsFor(&ResourceReference{Kind: "service", Name: "foo"})
+	require.Equal(t, []Condition{
+		{Type: "Accepted", Status: ConditionStatusTrue},
+		{Type: "ResolvedRefs", Status: ConditionStatusFalse, Resource: bar},
+	}, status.Conditions)
+	require.Equal(t, original, shallow.Conditions)
+
+	empty := Status{}
+	empty.RemoveConditionsFor(foo)
+	require.Empty(t, empty.Conditions)
+}
+
+func TestSortConditions(t *testing.T) {
+	gateway := &ResourceReference{Kind: APIGateway, Name: "gateway"}
+	listener := &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}
+
+	conditions := []Condition{
+		{Type: "ResolvedRefs", Reason: "ResolvedRefs", Resource: listener},
+		{Type: "Accepted", Reason: "Accepted", Resource: listener},
+		{Type: "Accepted", Reason: "Pending", Resource: gateway},
+		{Type: "Accepted", Reason: "Accepted", Resource: gateway},
+		{Type: "Accepted", Reason: "Accepted"},
+		{Type: "Accepted", Reason: "Accepted", Message: "second", Resource: gateway},
+	}
+	SortConditions(conditions)
+
+	require.Equal(t, []Condition{
+		{Type: "Accepted", Reason: "Accepted"},
+		{Type: "Accepted", Reason: "Accepted", Resource: gateway},
+		{Type: "Accepted", Reason: "Accepted", Message: "second", Resource: gateway},
+		{Type: "Accepted", Reason: "Pending", Resource: gateway},
+		{Type: "Accepted", Reason: "Accepted", Resource: listener},
+		{Type: "ResolvedRefs", Reason: "ResolvedRefs", Resource: listener},
+	}, conditions)
+}
+
+func TestConditionsEqual(t *testing.T) {
+	earlier := time.Now().Add(-time.Hour)
+	now := time.Now()
+	gateway := &ResourceReference{Kind: APIGateway, Name: "gateway"}
+
+	stored := []Condition{
+		{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted", Resource: gateway, LastTransitionTime: &earlier},
+		{Type: "ResolvedRefs", Status: ConditionStatusTrue, Reason: "ResolvedRefs", LastTransitionTime: &earlier},
+	}
+	computed := []Condition{
+		{Type: "ResolvedRefs", Status: ConditionStatusTrue, Reason: "ResolvedRefs", LastTransitionTime: &now},
+		{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted", Resource: &ResourceReference{Kind: APIGateway, Name: "gateway"}, LastTransitionTime: &now},
+	}
+
+	require.True(t, ConditionsEqual(stored, computed))
+	require.True(t, ConditionsEqual(nil, []Condition{}))
+
+	// the inputs are left untouched
+	require.Equal(t, "ResolvedRefs", computed[0].Type)
+	require.Equal(t, &now, computed[0].LastTransitionTime)
+
+	computed[0].Message = "changed"
+	require.False(t, ConditionsEqual(stored, computed))
+
+	require.False(t, ConditionsEqual(stored, stored[:1]))
+
+	// a stored condition that has flipped before still matches a freshly
+	// computed one, and empty details match unset ones
+	flipped := []Condition{
+		{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted", TransitionCount: 3, Details: map[string]string{}},
+	}
+	fresh := []Condition{
+		{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted"},
+	}
+	require.True(t, ConditionsEqual(flipped, fresh))
+	require.Equal(t, uint(3), flipped[0].TransitionCount)
+	require.NotNil(t, flipped[0].Details)
+
+	fresh[0].Details = map[string]string{"listener_port": "8080"}
+	require.False(t, ConditionsEqual(flipped, fresh))
+}
+
+func TestStatus_MergeDetectConflicts(t *testing.T) {
+	gateway := &ResourceReference{Kind: APIGateway, Name: "gateway"}
+	listener := &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "listener"}
+
+	existing := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue, Resource: gateway},
+			{Type: "Accepted", Status: ConditionStatusTrue, Resource: listener},
+			{Type: "ResolvedRefs", Status: ConditionStatusTrue},
+		},
+	}
+	desired := Status{
+		Conditions: []Condition{
+			{Type: "Accepted", Status: ConditionStatusTrue, Reason: "Accepted", Resource: gateway},
+			{Type: "Accepted", Status: ConditionStatusFalse, Reason: "Invalid", Resource: listener},
+			{Type: "ResolvedRefs", Status: ConditionStatusUnknown},
+			{Type: "Conflicted", Status: ConditionStatusFalse},
+		},
+	}
+
+	conflicts := existing.MergeDetectConflicts(&desired)
+	require.Equal(t, []ConditionConflict{
+		{Existing: Condition{Type: "Accepted", Status: ConditionStatusTrue, Resource: listener}, Desired: desired.Conditions[1]},
+		{Existing: Condition{Type: "ResolvedRefs", Status: ConditionStatusTrue}, Desired: desired.Conditions[2]},
+	}, conflicts)
+	require.Len(t, existing.Conditions, 4)
+	require.Equal(t, desired.Conditions[3], existing.Conditions[3])
+
+	// merging the same status again produces no conflicts
+	require.Empty(t, existing.MergeDetectConflicts(&desired))
+	require.Len(t, existing.Conditions, 4)
+}
+
+func TestStatus_MergeDetectConflictsTransitionCount(t *testing.T) {
+	status := Status{}
+	merge := func(conditionStatus ConditionStatus, message string) {
+		status.MergeDetectConflicts(&Status{
+			Conditions: []Condition{{Type: "Accepted", Status: conditionStatus, Message: message}},
+		})
+	}
+
+	merge(ConditionStatusTrue, "")
+	require.Equal(t, uint(0), status.Conditions[0].TransitionCount)
+
+	// only the message changed, which is not a transition
+	merge(ConditionStatusTrue, "still accepted")
+	require.Equal(t, uint(0), status.Conditions[0].TransitionCount)
+
+	merge(ConditionStatusFalse, "")
+	require.Equal(t, uint(1), status.Conditions[0].TransitionCount)
+
+	merge(ConditionStatusFalse, "")
+	require.Equal(t, uint(1), status.Conditions[0].TransitionCount)
+
+	merge(ConditionStatusTrue, "")
+	require.Equal(t, uint(2), status.Conditions[0].TransitionCount)
+	require.Len(t, status.Conditions, 1)
+}
+
+func TestJoinConditionMessages(t *testing.T) {
+	cases := map[string]struct {
+		msgs     []string
+		expected string
+	}{
+		"empty":  {expected: ""},
+		"single": {msgs: []string{"service foo not found"}, expected: "service foo not found"},
+		"joined": {
+			msgs:     []string{"service foo not found", "service bar not found"},
+			expected: "service foo not found; service bar not found",
+		},
+		"deduplicated": {
+			msgs:     []string{"service foo not found", "", "listener mismatch", "service foo not found"},
+			expected: "service foo not found; listener mismatch",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, JoinConditionMessages(tc.msgs))
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		message := JoinConditionMessages([]string{
+			strings.Repeat("a", maxConditionMessageLength/2),
+			strings.Repeat("b", maxConditionMessageLength/2),
+		})
+		require.Len(t, message, maxConditionMessageLength)
+		require.True(t, strings.HasSuffix(message, "b..."))
+	})
+
+	t.Run("truncated on rune boundary", func(t *testing.T) {
+		message := JoinConditionMessages([]string{strings.Repeat("é", maxConditionMessageLength)})
+		require.True(t, utf8.ValidString(message))
+		require.LessOrEqual(t, len(message), maxConditionMessageLength)
+		require.True(t, strings.HasSuffix(message, "é..."))
+	})
+}
+
+func TestCondition_Clone(t *testing.T) {
+	now := time.Now()
+	condition := Condition{
+		Type:               "Accepted",
+		Status:             ConditionStatusTrue,
+		Resource:           &ResourceReference{Kind: APIGateway, Name: "gateway"},
+		LastTransitionTime: &now,
+		Details:            map[string]string{"listener_port": "8080"},
+	}
+
+	clone := condition.Clone()
+	require.Equal(t, condition, clone)
+	require.NotSame(t, condition.Resource, clone.Resource)
+	require.NotSame(t, condition.LastTransitionTime, clone.LastTransitionTime)
+
+	clone.Details["listener_port"] = "9090"
+	require.Equal(t, "8080", condition.Details["listener_port"])
+
+	require.Equal(t, Condition{}, Condition{}.Clone())
+}
+
+func TestCondition_CloneConcurrentReaders(t *testing.T) {
+	var (
+		lock      sync.Mutex
+		now       = time.Now()
+		condition = Condition{
+			Resource:           &ResourceReference{Kind: APIGateway, Name: "gateway"},
+			LastTransitionTime: &now,
+		}
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				lock.Lock()
+				clone := condition.Clone()
+				lock.Unlock()
+
+				// reading the clone outside of the lock must not race with the writer
+				_ = clone.Resource.Name
+				_ = clone.LastTransitionTime.String()
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		lock.Lock()
+		condition.Resource.Name = fmt.Sprintf("gateway-%d", i)
+		*condition.LastTransitionTime = time.Now()
+		lock.Unlock()
+	}
+	wg.Wait()
+}
+
+func TestStatus_AsError(t *testing.T) {
+	t.Run("all true or unknown", func(t *testing.T) {
+		status := Status{
+			Conditions: []Condition{
+				{Type: "Accepted", Status: ConditionStatusTrue},
+				{Type: "ResolvedRefs", Status: ConditionStatusUnknown},
+			},
+		}
+		require.NoError(t, status.AsError())
+		require.NoError(t, (&Status{}).AsError())
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		status := Status{
+			Conditions: []Condition{
+				{Type: "Accepted", Status: ConditionStatusFalse, Reason: "NotAllowed", Message: "listener does not allow routes"},
+				{Type: "Conflicted", Status: ConditionStatusTrue},
+				{Type: "ResolvedRefs", Status: ConditionStatusFalse, Reason: "BackendNotFound", Message: "service foo not found"},
+			},
+		}
+		err := status.AsError()
+		require.Error(t, err)
+
+		merr, ok := err.(*multierror.Error)
+		require.True(t, ok)
+		require.Len(t, merr.Errors, 2)
+		require.EqualError(t, merr.Errors[0], `condition "Accepted" is False with reason "NotAllowed": listener does not allow routes`)
+		require.EqualError(t, merr.Errors[1], `condition "ResolvedRefs" is False with reason "BackendNotFound": service foo not found`)
+	})
+}
+
+func TestCondition_MarshalJSON(t *testing.T) {
+	transition := time.Date(2023, 1, 2, 3, 4, 5, 678910, time.UTC)
+	condition := Condition{
+		Type:               "Accepted",
+		Status:             ConditionStatusFalse,
+		Reason:             "NotAllowed",
+		Message:            "listener does not allow routes",
+		LastTransitionTime: &transition,
+	}
+
+	encoded, err := json.Marshal(condition)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Type": "Accepted",
+		"Status": "False",
+		"Reason": "NotAllowed",
+		"Message": "listener does not allow routes",
+		"LastTransitionTime": "2023-01-02T03:04:05Z"
+	}`, string(encoded))
+
+	var decoded Condition
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, condition.Type, decoded.Type)
+	require.Equal(t, condition.Status, decoded.Status)
+	require.Equal(t, condition.Reason, decoded.Reason)
+	require.Equal(t, condition.Message, decoded.Message)
+	require.Nil(t, decoded.Resource)
+	require.Equal(t, transition.Truncate(time.Second), *decoded.LastTransitionTime)
+
+	// unset pointers are omitted entirely
+	encoded, err = json.Marshal(Condition{Type: "Accepted", Status: ConditionStatusTrue})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Type": "Accepted", "Status": "True", "Reason": "", "Message": ""}`, string(encoded))
+
+	// and resources are kept when set
+	condition.Resource = &ResourceReference{Kind: APIGateway, Name: "gateway"}
+	encoded, err = json.Marshal([]Condition{condition})
+	require.NoError(t, err)
+
+	var decodedList []Condition
+	require.NoError(t, json.Unmarshal(encoded, &decodedList))
+	require.Equal(t, condition.Resource, decodedList[0].Resource)
+}
+
+func TestCondition_Details(t *testing.T) {
+	t.Run("serialization", func(t *testing.T) {
+		condition := Condition{Type: "ResolvedRefs", Status: ConditionStatusFalse}
+
+		encoded, err := json.Marshal(condition)
+		require.NoError(t, err)
+		require.NotContains(t, string(encoded), "Details")
+
+		condition.Details = map[string]string{"backend_kind": "service"}
+		encoded, err = json.Marshal(condition)
+		require.NoError(t, err)
+		require.Contains(t, string(encoded), `"Details":{"backend_kind":"service"}`)
+
+		var decoded Condition
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		require.Equal(t, condition, decoded)
+	})
+
+	t.Run("size bounds", func(t *testing.T) {
+		condition := Condition{Details: map[string]string{"listener_port": "8080"}}
+		require.NoError(t, condition.Validate())
+
+		condition.Details = make(map[string]string)
+		for i := 0; i <= metaMaxKeyPairs; i++ {
+			condition.Details[fmt.Sprintf("key-%d", i)] = "value"
+		}
+		require.ErrorContains(t, condition.Validate(), "Details exceeds maximum element count")
+
+		condition.Details = map[string]string{strings.Repeat("k", metaKeyMaxLength+1): "value"}
+		require.ErrorContains(t, condition.Validate(), "exceeds maximum length")
+
+		condition.Details = map[string]string{"key": strings.Repeat("v", metaValueMaxLength+1)}
+		require.ErrorContains(t, condition.Validate(), `Details value for key "key" exceeds maximum length`)
+	})
+}
+
+func TestCondition_ReasonKnown(t *testing.T) {
+	cases := map[string]struct {
+		condition Condition
+		known     bool
+	}{
+		"gateway reason": {
+			condition: Condition{Type: "Accepted", Status: ConditionStatusFalse, Reason: "ListenersNotValid"},
+			known:     true,
+		},
+		"listener reason": {
+			condition: Condition{Type: "Programmed", Status: ConditionStatusFalse, Reason: "Invalid"},
+			known:     true,
+		},
+		"reason for other status": {
+			condition: Condition{Type: "Accepted", Status: ConditionStatusTrue, Reason: "ListenersNotValid"},
+			known:     false,
+		},
+		"unknown reason": {
+			condition: Condition{Type: "Accepted", Status: ConditionStatusFalse, Reason: "Misconfigured"},
+			known:     false,
+		},
+		"unknown type": {
+			condition: Condition{Type: "Ready", Status: ConditionStatusTrue, Reason: "Accepted"},
+			known:     false,
+		},
+		"invalid status": {
+			condition: Condition{Type: "Accepted", Status: "Maybe", Reason: "Accepted"},
+			known:     false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.known, tc.condition.ReasonKnown())
+		})
+	}
+}
+
+func TestDumpConditionRegistry(t *testing.T) {
+	registry := DumpConditionRegistry()
+
+	// gateway entries appear
+	require.Contains(t, registry, "Degraded")
+	require.Equal(t, []string{"SomeListenersInvalid"}, registry["Degraded"][ConditionStatusTrue])
+	require.Contains(t, registry["Accepted"][ConditionStatusFalse], "ListenersNotValid")
+
+	// listener entries appear and are merged with gateway entries of the same type
+	require.Contains(t, registry, "Programmed")
+	require.Contains(t, registry["Accepted"][ConditionStatusFalse], "PortUnavailable")
+	require.ElementsMatch(t, []string{"Accepted"}, registry["Accepted"][ConditionStatusTrue])
+
+	// the dump is a copy of the package tables
+	registry["Degraded"][ConditionStatusTrue][0] = "Changed"
+	delete(registry, "Programmed")
+	require.Equal(t, GatewayReasonSomeListenersInvalid, gatewayConditionReasons[GatewayConditionDegraded][ConditionStatusTrue][0])
+	require.Contains(t, DumpConditionRegistry(), "Programmed")
+}
+
+func TestCondition_ValidateLastTransitionTime(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		transition := now.Add(d)
+		return &transition
+	}
+
+	cases := map[string]struct {
+		transition *time.Time
+		err        string
+	}{
+		"unset":            {},
+		"past":             {transition: at(-time.Hour)},
+		"now":              {transition: at(0)},
+		"within tolerance": {transition: at(maxConditionClockSkew)},
+		"far future": {
+			transition: at(24 * time.Hour),
+			err:        "LastTransitionTime 2023-01-02T12:00:00Z is in the future",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Condition{LastTransitionTime: tc.transition}.ValidateAt(now)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestStatus_Digest(t *testing.T) {
+	before := time.Now().Add(-time.Hour)
+	after := time.Now()
+	newStatus := func(transition *time.Time, message string) *Status {
+		return &Status{
+			Conditions: []Condition{
+				{
+					Type:               "Accepted",
+					Status:             ConditionStatusTrue,
+					Resource:           &ResourceReference{Kind: APIGateway, Name: "gateway"},
+					LastTransitionTime: transition,
+				},
+				{
+					Type:               "ResolvedRefs",
+					Status:             ConditionStatusFalse,
+					Reason:             "BackendNotFound",
+					Message:            message,
+					LastTransitionTime: transition,
+				},
+			},
+		}
+	}
+
+	digest := newStatus(&before, "service foo not found").Digest()
+	require.NotEmpty(t, digest)
+
+	// timestamps don't affect the digest
+	require.Equal(t, digest, newStatus(&after, "service foo not found").Digest())
+	require.Equal(t, digest, newStatus(nil, "service foo not found").Digest())
+
+	// neither does ordering
+	reversed := newStatus(&before, "service foo not found")
+	reversed.Conditions[0], reversed.Conditions[1] = reversed.Conditions[1], reversed.Conditions[0]
+	require.Equal(t, digest, reversed.Digest())
+
+	// but message changes do
+	require.NotEqual(t, digest, newStatus(&before, "service bar not found").Digest())
+}
+
+func TestStatus_ToK8sConditions(t *testing.T) {
+	transition := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := &Status{
+		Conditions: []Condition{
+			{
+				Type:               "Accepted",
+				Status:             ConditionStatusTrue,
+				Reason:             "Accepted",
+				Message:            "gateway accepted",
+				LastTransitionTime: &transition,
+				ObservedGeneration: 7,
+			},
+			{
+				Type:    "ResolvedRefs",
+				Status:  ConditionStatusFalse,
+				Reason:  "InvalidCertificates",
+				Message: "certificate not found",
+			},
+			// duplicate types and untyped conditions are dropped
+			{Type: "Accepted", Status: ConditionStatusFalse, Reason: "Invalid"},
+			{Status: ConditionStatusTrue},
+		},
+	}
+
+	k8sConditions := status.ToK8sConditions()
+	require.Equal(t, []K8sCondition{
+		{
+			Type:               "Accepted",
+			Status:             ConditionStatusTrue,
+			ObservedGeneration: 7,
+			LastTransitionTime: transition,
+			Reason:             "Accepted",
+			Message:            "gateway accepted",
+		},
+		{
+			Type:    "ResolvedRefs",
+			Status:  ConditionStatusFalse,
+			Reason:  "InvalidCertificates",
+			Message: "certificate not found",
+		},
+	}, k8sConditions)
+
+	// converting back yields the original conditions
+	for i, k8sCondition := range k8sConditions {
+		require.Equal(t, status.Conditions[i], ConditionFromK8s(k8sCondition))
+	}
+
+	// the JSON form matches the Kubernetes field names
+	encoded, err := json.Marshal(k8sConditions[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "Accepted",
+		"status": "True",
+		"observedGeneration": 7,
+		"lastTransitionTime": "2023-01-02T03:04:05Z",
+		"reason": "Accepted",
+		"message": "gateway accepted"
+	}`, string(encoded))
+
+	require.Nil(t, (&Status{}).ToK8sConditions())
+}
+
+func TestCondition_ObservedGeneration(t *testing.T) {
+	// statuses serialized before the field existed still decode
+	var condition Condition
+	require.NoError(t, json.Unmarshal([]byte(`{"Type":"Accepted","Status":"True","Reason":"Accepted"}`), &condition))
+	require.Equal(t, int64(0), condition.ObservedGeneration)
+
+	encoded, err := json.Marshal(condition)
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "ObservedGeneration")
+
+	condition.ObservedGeneration = 42
+	encoded, err = json.Marshal(condition)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"ObservedGeneration":42`)
+
+	var decoded Condition
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, condition, decoded)
+}
+
+func TestCondition_ControlledBy(t *testing.T) {
+	condition := Condition{Type: "Accepted", Status: ConditionStatusTrue}
+
+	encoded, err := json.Marshal(condition)
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "ControlledBy")
+
+	condition.ControlledBy = "api-gateway-controller"
+	encoded, err = json.Marshal(condition)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"ControlledBy":"api-gateway-controller"`)
+
+	var decoded Condition
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, condition, decoded)
+
+	// attribution survives a round trip through the raft encoding as well
+	entry := &APIGatewayConfigEntry{
+		Kind:   APIGateway,
+		Name:   "gateway",
+		Status: Status{Conditions: []Condition{condition}},
+	}
+	buf, err := Encode(ConfigEntryRequestType, &ConfigEntryRequest{Entry: entry})
+	require.NoError(t, err)
+
+	var req ConfigEntryRequest
+	require.NoError(t, Decode(buf[1:], &req))
+	require.Equal(t, "api-gateway-controller", req.Entry.(*APIGatewayConfigEntry).Status.Conditions[0].ControlledBy)
+
+	// the constructors set it via an option
+	gatewayCondition, err := NewGatewayCondition(GatewayConditionAccepted, ConditionStatusTrue, GatewayReasonAccepted, "",
+		&ResourceReference{Kind: APIGateway, Name: "gateway"}, WithControlledBy("api-gateway-controller"))
+	require.NoError(t, err)
+	require.Equal(t, "api-gateway-controller", gatewayCondition.ControlledBy)
+
+	listenerCondition, err := NewListenerCondition(ListenerConditionAccepted, ConditionStatusTrue, ListenerReasonAccepted, "",
+		&ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "http"}, WithControlledBy("api-gateway-controller"))
+	require.NoError(t, err)
+	require.Equal(t, "api-gateway-controller", listenerCondition.ControlledBy)
+
+	gatewayCondition, err = NewGatewayCondition(GatewayConditionAccepted, ConditionStatusTrue, GatewayReasonAccepted, "", nil)
+	require.NoError(t, err)
+	require.Empty(t, gatewayCondition.ControlledBy)
+}
+
+func TestNewGatewayCondition(t *testing.T) {
+	certificate := &ResourceReference{Kind: InlineCertificate, Name: "certificate"}
+
+	cases := map[string]struct {
+		name   GatewayConditionType
+		status ConditionStatus
+		reason GatewayConditionReason
+		err    string
+	}{
+		"accepted": {
+			name:   GatewayConditionAccepted,
+			status: ConditionStatusTrue,
+			reason: GatewayReasonAccepted,
+		},
+		"pending": {
+			name:   GatewayConditionAccepted,
+			status: ConditionStatusUnknown,
+			reason: GatewayReasonPending,
+		},
+		"conflicted": {
+			name:   GatewayConditionConflicted,
+			status: ConditionStatusTrue,
+			reason: GatewayReasonRouteConflict,
+		},
+		"cross gateway conflict": {
+			name:   GatewayConditionConflicted,
+			status: ConditionStatusTrue,
+			reason: GatewayReasonCrossGatewayConflict,
+		},
+		"cross gateway conflict without conflict": {
+			name:   GatewayConditionConflicted,
+			status: ConditionStatusFalse,
+			reason: GatewayReasonCrossGatewayConflict,
+			err:    `gateway condition reason "CrossGatewayConflict" not allowed for gateway condition type "Conflicted" with status "False"`,
+		},
+		"degraded": {
+			name:   GatewayConditionDegraded,
+			status: ConditionStatusTrue,
+			reason: GatewayReasonSomeListenersInvalid,
+		},
+		"not degraded": {
+			name:   GatewayConditionDegraded,
+			status: ConditionStatusFalse,
+			reason: GatewayReasonAllListenersValid,
+		},
+		"degraded with all listeners valid": {
+			name:   GatewayConditionDegraded,
+			status: ConditionStatusTrue,
+			reason: GatewayReasonAllListenersValid,
+			err:    `gateway condition reason "AllListenersValid" not allowed for gateway condition type "Degraded" with status "True"`,
+		},
+		"unsupported address": {
+			name:   GatewayConditionAccepted,
+			status: ConditionStatusFalse,
+			reason: GatewayReasonUnsupportedAddress,
+		},
+		"unsupported address for resolved refs": {
+			name:   GatewayConditionResolvedRefs,
+			status: ConditionStatusFalse,
+			reason: GatewayReasonUnsupportedAddress,
+			err:    `gateway condition reason "UnsupportedAddress" not allowed for gateway condition type "ResolvedRefs" with status "False"`,
+		},
+		"invalid certificates": {
+			name:   GatewayConditionResolvedRefs,
+			status: ConditionStatusFalse,
+			reason: GatewayReasonInvalidCertificates,
+		},
+		"reason for wrong status": {
+			name:   GatewayConditionAccepted,
+			status: ConditionStatusFalse,
+			reason: GatewayReasonAccepted,
+			err:    `gateway condition reason "Accepted" not allowed for gateway condition type "Accepted" with status "False"`,
+		},
+		"no reasons for status": {
+			name:   GatewayConditionConflicted,
+			status: ConditionStatusUnknown,
+			reason: GatewayReasonPending,
+			err:    `gateway condition reason "Pending" not allowed for gateway condition type "Conflicted" with status "Unknown"`,
+		},
+		"unknown type": {
+			name:   "Programmed",
+			status: ConditionStatusTrue,
+			reason: GatewayReasonAccepted,
+			err:    `unrecognized gateway condition type: "Programmed"`,
+		},
+		"invalid status": {
+			name:   GatewayConditionAccepted,
+			status: "Maybe",
+			reason: GatewayReasonAccepted,
+			err:    `unrecognized condition status: "Maybe"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			condition, err := NewGatewayCondition(tc.name, tc.status, tc.reason, "message", certificate)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, string(tc.name), condition.Type)
+			require.Equal(t, tc.status, condition.Status)
+			require.Equal(t, string(tc.reason), condition.Reason)
+			require.Equal(t, "message", condition.Message)
+			require.Equal(t, certificate, condition.Resource)
+			require.NotNil(t, condition.LastTransitionTime)
+		})
+	}
+}
+
+func TestNewListenerCondition(t *testing.T) {
+	listener := &ResourceReference{Kind: APIGateway, Name: "gateway", SectionName: "http"}
+
+	cases := map[string]struct {
+		name     ListenerConditionType
+		status   ConditionStatus
+		reason   ListenerConditionReason
+		resource *ResourceReference
+		err      string
+	}{
+		"accepted": {
+			name:     ListenerConditionAccepted,
+			status:   ConditionStatusTrue,
+			reason:   ListenerReasonAccepted,
+			resource: listener,
+		},
+		"hostname conflict": {
+			name:     ListenerConditionConflicted,
+			status:   ConditionStatusTrue,
+			reason:   ListenerReasonHostnameConflict,
+			resource: listener,
+		},
+		"invalid certificate": {
+			name:     ListenerConditionResolvedRefs,
+			status:   ConditionStatusFalse,
+			reason:   ListenerReasonInvalidCertificateRef,
+			resource: listener,
+		},
+		"programming pending": {
+			name:     ListenerConditionProgrammed,
+			status:   ConditionStatusUnknown,
+			reason:   ListenerReasonPending,
+			resource: listener,
+		},
+		"missing resource": {
+			name:   ListenerConditionAccepted,
+			status: ConditionStatusTrue,
+			reason: ListenerReasonAccepted,
+			err:    `listener condition "Accepted" requires a resource with a SectionName`,
+		},
+		"missing section name": {
+			name:     ListenerConditionAccepted,
+			status:   ConditionStatusTrue,
+			reason:   ListenerReasonAccepted,
+			resource: &ResourceReference{Kind: APIGateway, Name: "gateway"},
+			err:      `listener condition "Accepted" requires a resource with a SectionName`,
+		},
+		"reason for wrong status": {
+			name:     ListenerConditionProgrammed,
+			status:   ConditionStatusTrue,
+			reason:   ListenerReasonInvalid,
+			resource: listener,
+			err:      `listener condition reason "Invalid" not allowed for listener condition type "Programmed" with status "True"`,
+		},
+		"unknown type": {
+			name:     "Detached",
+			status:   ConditionStatusTrue,
+			reason:   ListenerReasonAccepted,
+			resource: listener,
+			err:      `unrecognized listener condition type: "Detached"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			condition, err := NewListenerCondition(tc.name, tc.status, tc.reason, "message", tc.resource)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, string(tc.name), condition.Type)
+			require.Equal(t, tc.status, condition.Status)
+			require.Equal(t, string(tc.reason), condition.Reason)
+			require.Equal(t, tc.resource, condition.Resource)
+			require.NotNil(t, condition.LastTransitionTime)
+		})
+	}
+}
+
+func TestGatewayConditionReasonsWellFormed(t *testing.T) {
+	declaredTypes := []GatewayConditionType{
+		GatewayConditionAccepted,
+		GatewayConditionConflicted,
+		GatewayConditionResolvedRefs,
+		GatewayConditionDegraded,
+	}
+	declaredReasons := []GatewayConditionReason{
+		GatewayReasonAccepted,
+		GatewayReasonInvalidCertificates,
+		GatewayReasonListenersNotValid,
+		GatewayReasonUnsupportedAddress,
+		GatewayReasonPending,
+		GatewayReasonRouteConflict,
+		GatewayReasonCrossGatewayConflict,
+		GatewayReasonNoConflict,
+		GatewayReasonResolvedRefs,
+		GatewayReasonSomeListenersInvalid,
+		GatewayReasonAllListenersValid,
+	}
+
+	for name, statuses := range gatewayConditionReasons {
+		require.Contains(t, declaredTypes, name)
+		for status, reasons := range statuses {
+			require.NoError(t, checkConditionStatus(status), "condition %q", name)
+
+			seen := make(map[GatewayConditionReason]struct{})
+			for _, reason := range reasons {
+				require.Contains(t, declaredReasons, reason, "condition %q with status %q", name, status)
+				require.NotContains(t, seen, reason, "duplicate reason for condition %q with status %q", name, status)
+				seen[reason] = struct{}{}
+			}
+		}
+	}
+}
+
+func TestListenerConditionReasonsWellFormed(t *testing.T) {
+	declaredTypes := []ListenerConditionType{
+		ListenerConditionAccepted,
+		ListenerConditionConflicted,
+		ListenerConditionResolvedRefs,
+		ListenerConditionProgrammed,
+	}
+	declaredReasons := []ListenerConditionReason{
+		ListenerReasonAccepted,
+		ListenerReasonPortUnavailable,
+		ListenerReasonUnsupportedProtocol,
+		ListenerReasonPending,
+		ListenerReasonHostnameConflict,
+		ListenerReasonProtocolConflict,
+		ListenerReasonNoConflicts,
+		ListenerReasonResolvedRefs,
+		ListenerReasonInvalidCertificateRef,
+		ListenerReasonInvalidRouteKinds,
+		ListenerReasonProgrammed,
+		ListenerReasonInvalid,
+	}
+
+	for name, statuses := range listenerConditionReasons {
+		require.Contains(t, declaredTypes, name)
+		for status, reasons := range statuses {
+			require.NoError(t, checkConditionStatus(status), "condition %q", name)
+
+			seen := make(map[ListenerConditionReason]struct{})
+			for _, reason := range reasons {
+				require.Contains(t, declaredReasons, reason, "condition %q with status %q", name, status)
+				require.NotContains(t, seen, reason, "duplicate reason for condition %q with status %q", name, status)
+				seen[reason] = struct{}{}
+			}
+		}
+	}
+}
@@ -43,6 +43,7 @@ deep-copy \
   -type ServiceRoute \
   -type ServiceRouteDestination \
   -type ServiceRouteMatch \
+  -type Status \
   -type Upstream \
   -type UpstreamConfiguration \
   ./
@@ -1,8 +1,10 @@
-// generated by deep-copy -pointer-receiver -o ./structs.deepcopy.go -type CARoot -type CheckServiceNode -type CheckType -type CompiledDiscoveryChain -type ConnectProxyConfig -type DiscoveryFailover -type DiscoveryGraphNode -type DiscoveryResolver -type DiscoveryRoute -type DiscoverySplit -type ExposeConfig -type GatewayService -type GatewayServiceTLSConfig -type HTTPHeaderModifiers -type HashPolicy -type HealthCheck -type IndexedCARoots -type IngressListener -type Intention -type IntentionPermission -type LoadBalancer -type MeshConfigEntry -type MeshDirectionalTLSConfig -type MeshTLSConfig -type Node -type NodeService -type PeeringServiceMeta -type ServiceConfigEntry -type ServiceConfigResponse -type ServiceConnect -type ServiceDefinition -type ServiceResolverConfigEntry -type ServiceResolverFailover -type ServiceRoute -type ServiceRouteDestination -type ServiceRouteMatch -type Upstream -type UpstreamConfiguration ./; DO NOT EDIT.
+// generated by deep-copy -pointer-receiver -o ./structs.deepcopy.go -type CARoot -type CheckServiceNode -type CheckType -type CompiledDiscoveryChain -type ConnectProxyConfig -type DiscoveryFailover -type DiscoveryGraphNode -type DiscoveryResolver -type DiscoveryRoute -type DiscoverySplit -type ExposeConfig -type GatewayService -type GatewayServiceTLSConfig -type HTTPHeaderModifiers -type HashPolicy -type HealthCheck -type IndexedCARoots -type IngressListener -type Intention -type IntentionPermission -type LoadBalancer -type MeshConfigEntry -type MeshDirectionalTLSConfig -type MeshTLSConfig -type Node -type NodeService -type PeeringServiceMeta -type ServiceConfigEntry -type ServiceConfigResponse -type ServiceConnect -type ServiceDefinition -type ServiceResolverConfigEntry -type ServiceResolverFailover -type ServiceRoute -type ServiceRouteDestination -type ServiceRouteMatch -type Status -type Upstream -type UpstreamConfiguration ./; DO NOT EDIT.
 
 package structs
 
 import (
+	"time"
+
 	"github.com/hashicorp/consul/types"
 )
 
@@ -808,6 +810,32 @@ func (o *ServiceRouteMatch) DeepCopy() *ServiceRouteMatch {
 	return &cp
 }
 
+// DeepCopy generates a deep copy of *Status
+func (o *Status) DeepCopy() *Status {
+	var cp Status = *o
+	if o.Conditions != nil {
+		cp.Conditions = make([]Condition, len(o.Conditions))
+		copy(cp.Conditions, o.Conditions)
+		for i2 := range o.Conditions {
+			if o.Conditions[i2].Resource != nil {
+				cp.Conditions[i2].Resource = new(ResourceReference)
+				*cp.Conditions[i2].Resource = *o.Conditions[i2].Resource
+			}
+			if o.Conditions[i2].LastTransitionTime != nil {
+				cp.Conditions[i2].LastTransitionTime = new(time.Time)
+				*cp.Conditions[i2].LastTransitionTime = *o.Conditions[i2].LastTransitionTime
+			}
+			if o.Conditions[i2].Details != nil {
+				cp.Conditions[i2].Details = make(map[string]string, len(o.Conditions[i2].Details))
+				for k4, v4 := range o.Conditions[i2].Details {
+					cp.Conditions[i2].Details[k4] = v4
+				}
+			}
+		}
+	}
+	return &cp
+}
+
 // DeepCopy generates a deep copy of *Upstream
 func (o *Upstream) DeepCopy() *Upstream {
 	var cp Upstream = *o
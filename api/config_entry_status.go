@@ -40,6 +40,8 @@ type ConfigEntryStatus struct {
 // multiple other resources may have different statuses with
 // respect to each of those resources.
 type Condition struct {
+	// Type is a value from a bounded set of types that an object might have
+	Type string
 	// Status is a value from a bounded set of statuses that an object might have
 	Status string
 	// Reason is a value from a bounded set of reasons for a given status
@@ -52,4 +54,17 @@ type Condition struct {
 	Resource *ResourceReference
 	// LastTransitionTime is the time at which this Condition was created
 	LastTransitionTime *time.Time
+	// ObservedGeneration is the ModifyIndex of the ConfigEntry that this
+	// Condition was computed from, and can be compared against the entry's
+	// current ModifyIndex to tell whether the Condition is up to date
+	ObservedGeneration int64 `json:",omitempty"`
+	// TransitionCount is the number of times the Status of this Condition
+	// has changed, and can be used to detect flapping conditions
+	TransitionCount uint `json:",omitempty"`
+	// Details is an optional set of machine-readable key/value pairs
+	// that give additional context about the Condition
+	Details map[string]string `json:",omitempty"`
+	// ControlledBy optionally identifies the controller that set
+	// this Condition
+	ControlledBy string `json:",omitempty"`
 }
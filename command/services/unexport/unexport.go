@@ -0,0 +1,237 @@
+package unexport
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	// flags
+	serviceName        string
+	serviceNamespace   string
+	consumerPeers      string
+	consumerPartitions string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.serviceName, "name", "",
+		"(Required) The name of the exported service to stop exporting.")
+	c.flags.StringVar(&c.serviceNamespace, "namespace", "",
+		"The namespace of the exported service. Namespaces are a Consul Enterprise feature.")
+	c.flags.StringVar(&c.consumerPeers, "consumer-peers", "",
+		"A comma-separated list of peers to stop exporting the service to.")
+	c.flags.StringVar(&c.consumerPartitions, "consumer-partitions", "",
+		"A comma-separated list of admin partitions to stop exporting the service to. "+
+			"Partitions are a Consul Enterprise feature.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.PartitionFlag())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Check for any missing or invalid flag values.
+	if c.serviceName == "" {
+		c.UI.Error("A service name must be given via the -name flag.")
+		return 1
+	}
+	peers := splitList(c.consumerPeers)
+	partitions := splitList(c.consumerPartitions)
+	if len(peers) == 0 && len(partitions) == 0 {
+		c.UI.Error("At least one consumer must be given via the -consumer-peers or -consumer-partitions flags.")
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// The exported-services config entry is named after the partition it is stored in.
+	entryName := c.http.Partition()
+	if entryName == "" {
+		entryName = api.PartitionDefaultName
+	}
+
+	conf, _, err := client.ConfigEntries().Get(api.ExportedServices, entryName, &api.QueryOptions{Partition: c.http.Partition()})
+	if err != nil {
+		if strings.Contains(err.Error(), agent.ConfigEntryNotFoundErr) {
+			c.UI.Output(fmt.Sprintf("Service %q is not exported, nothing to do", c.serviceName))
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Error fetching existing exported services configuration: %s", err))
+		return 1
+	}
+
+	entry, ok := conf.(*api.ExportedServicesConfigEntry)
+	if !ok {
+		// This should never happen
+		c.UI.Error(fmt.Sprintf("Config entry is an invalid type: %T", conf))
+		return 1
+	}
+
+	serviceIdx := -1
+	for i, service := range entry.Services {
+		if service.Name == c.serviceName && namespaceMatch(service.Namespace, c.serviceNamespace) {
+			serviceIdx = i
+			break
+		}
+	}
+	if serviceIdx < 0 {
+		c.UI.Output(fmt.Sprintf("Service %q is not exported, nothing to do", c.serviceName))
+		return 0
+	}
+
+	service := entry.Services[serviceIdx]
+	var (
+		removed   []string
+		consumers []api.ServiceConsumer
+	)
+	for _, consumer := range service.Consumers {
+		switch {
+		case consumer.Peer != "" && contains(peers, consumer.Peer):
+			removed = append(removed, fmt.Sprintf("peer %q", consumer.Peer))
+		case consumer.Partition != "" && contains(partitions, consumer.Partition):
+			removed = append(removed, fmt.Sprintf("partition %q", consumer.Partition))
+		default:
+			consumers = append(consumers, consumer)
+		}
+	}
+
+	// Report any consumers that the service wasn't exported to in the first place.
+	for _, peer := range peers {
+		if !hasConsumer(service.Consumers, api.ServiceConsumer{Peer: peer}) {
+			c.UI.Output(fmt.Sprintf("Service %q is not exported to peer %q", c.serviceName, peer))
+		}
+	}
+	for _, partition := range partitions {
+		if !hasConsumer(service.Consumers, api.ServiceConsumer{Partition: partition}) {
+			c.UI.Output(fmt.Sprintf("Service %q is not exported to partition %q", c.serviceName, partition))
+		}
+	}
+
+	if len(removed) == 0 {
+		return 0
+	}
+
+	// Drop the service from the entry entirely if it no longer has any consumers.
+	if len(consumers) == 0 {
+		entry.Services = append(entry.Services[:serviceIdx], entry.Services[serviceIdx+1:]...)
+	} else {
+		entry.Services[serviceIdx].Consumers = consumers
+	}
+
+	// Write the updated config entry using a check-and-set, so it fails if the entry
+	// has been changed since we looked it up.
+	succeeded, _, err := client.ConfigEntries().CAS(entry, entry.GetModifyIndex(), &api.WriteOptions{Partition: c.http.Partition()})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error writing exported services config entry: %v", err))
+		return 1
+	}
+	if !succeeded {
+		c.UI.Error("Exported services config entry was changed while attempting to update, please try again.")
+		return 1
+	}
+
+	for _, consumer := range removed {
+		c.UI.Output(fmt.Sprintf("Service %q is no longer exported to %s", c.serviceName, consumer))
+	}
+	return 0
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasConsumer(consumers []api.ServiceConsumer, target api.ServiceConsumer) bool {
+	for _, consumer := range consumers {
+		if consumer == target {
+			return true
+		}
+	}
+	return false
+}
+
+func namespaceMatch(a, b string) bool {
+	if a == "" {
+		a = api.IntentionDefaultNamespace
+	}
+	if b == "" {
+		b = api.IntentionDefaultNamespace
+	}
+	return strings.EqualFold(a, b)
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Stop exporting a service to peers or partitions"
+	help     = `
+Usage: consul services unexport [options] -name <service name> -consumer-peers <other cluster name>
+
+  Removes consumers from a service in the exported-services config entry. If
+  the service is left without any consumers it is removed from the entry.
+
+  Stop exporting a service to a cluster peer:
+
+      $ consul services unexport -name=web -consumer-peers=other-cluster
+
+  Stop exporting a service to admin partitions:
+
+      $ consul services unexport -name=web -consumer-partitions=part1,part2
+
+  Consumers that the service is not exported to are reported and skipped.
+`
+)
@@ -0,0 +1,143 @@
+package unexport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+)
+
+func TestCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestCommand_Validation(t *testing.T) {
+	t.Parallel()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+
+	cases := map[string]struct {
+		args   []string
+		output string
+	}{
+		"no name": {
+			[]string{"-consumer-peers", "east"},
+			"A service name must be given via the -name flag.",
+		},
+		"no consumers": {
+			[]string{"-name", "web"},
+			"At least one consumer must be given",
+		},
+		"empty consumer list": {
+			[]string{"-name", "web", "-consumer-peers", " , "},
+			"At least one consumer must be given",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c.init()
+
+			// Ensure our buffer is always clear
+			if ui.ErrorWriter != nil {
+				ui.ErrorWriter.Reset()
+			}
+			if ui.OutputWriter != nil {
+				ui.OutputWriter.Reset()
+			}
+
+			require.Equal(t, 1, c.Run(tc.args))
+			require.Contains(t, ui.ErrorWriter.String(), tc.output)
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	run := func(t *testing.T, args ...string) (int, string) {
+		ui := cli.NewMockUi()
+		c := New(ui)
+		code := c.Run(append([]string{"-http-addr=" + a.HTTPAddr()}, args...))
+		require.Empty(t, ui.ErrorWriter.String())
+		return code, ui.OutputWriter.String()
+	}
+
+	t.Run("no config entry", func(t *testing.T) {
+		code, output := run(t, "-name=web", "-consumer-peers=east")
+		require.Equal(t, 0, code)
+		require.Contains(t, output, `Service "web" is not exported`)
+	})
+
+	_, _, err := client.ConfigEntries().Set(&api.ExportedServicesConfigEntry{
+		Name: "default",
+		Services: []api.ExportedService{
+			{
+				Name:      "web",
+				Consumers: []api.ServiceConsumer{{Peer: "east"}, {Peer: "west"}},
+			},
+			{
+				Name:      "db",
+				Consumers: []api.ServiceConsumer{{Peer: "east"}},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	readEntry := func(t *testing.T) *api.ExportedServicesConfigEntry {
+		entry, _, err := client.ConfigEntries().Get(api.ExportedServices, "default", nil)
+		require.NoError(t, err)
+		return entry.(*api.ExportedServicesConfigEntry)
+	}
+
+	t.Run("unknown service", func(t *testing.T) {
+		code, output := run(t, "-name=api", "-consumer-peers=east")
+		require.Equal(t, 0, code)
+		require.Contains(t, output, `Service "api" is not exported`)
+	})
+
+	t.Run("unknown consumer", func(t *testing.T) {
+		code, output := run(t, "-name=web", "-consumer-peers=north")
+		require.Equal(t, 0, code)
+		require.Contains(t, output, `Service "web" is not exported to peer "north"`)
+		require.Len(t, readEntry(t).Services[0].Consumers, 2)
+	})
+
+	t.Run("remove one consumer", func(t *testing.T) {
+		code, output := run(t, "-name=web", "-consumer-peers=east,north")
+		require.Equal(t, 0, code)
+		require.Contains(t, output, `Service "web" is not exported to peer "north"`)
+		require.Contains(t, output, `Service "web" is no longer exported to peer "east"`)
+
+		entry := readEntry(t)
+		require.Len(t, entry.Services, 2)
+		require.Equal(t, "web", entry.Services[0].Name)
+		require.Equal(t, []api.ServiceConsumer{{Peer: "west"}}, entry.Services[0].Consumers)
+	})
+
+	t.Run("remove last consumer", func(t *testing.T) {
+		code, output := run(t, "-name=web", "-consumer-peers=west")
+		require.Equal(t, 0, code)
+		require.Contains(t, output, `Service "web" is no longer exported to peer "west"`)
+
+		entry := readEntry(t)
+		require.Len(t, entry.Services, 1)
+		require.Equal(t, "db", entry.Services[0].Name)
+	})
+}
@@ -20,3 +20,9 @@ func EnterpriseMetaFromStructs(t *acl.EnterpriseMeta, s *EnterpriseMeta) {
 		return
 	}
 }
+
+// Scope returns the "partition/namespace" that the EnterpriseMeta refers to.
+// It is always empty in CE, matching acl.EnterpriseMeta.Scope.
+func (msg *EnterpriseMeta) Scope() string {
+	return ""
+}
@@ -0,0 +1,29 @@
+//go:build !consulent
+// +build !consulent
+
+package pbcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+)
+
+func TestEnterpriseMeta_Scope(t *testing.T) {
+	cases := map[string]*EnterpriseMeta{
+		"nil":       nil,
+		"empty":     {},
+		"populated": {Partition: "part1", Namespace: "team-a"},
+	}
+	for name, meta := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Empty(t, meta.Scope())
+
+			var structsMeta acl.EnterpriseMeta
+			EnterpriseMetaToStructs(meta, &structsMeta)
+			require.Equal(t, structsMeta.Scope(), meta.Scope())
+		})
+	}
+}
@@ -184,7 +184,7 @@ func ConditionToStructs(s *Condition, t *structs.Condition) {
 	if s == nil {
 		return
 	}
-	t.Status = s.Status
+	t.Status = structs.ConditionStatus(s.Status)
 	t.Reason = s.Reason
 	t.Message = s.Message
 	if s.Resource != nil {
@@ -193,12 +193,17 @@ func ConditionToStructs(s *Condition, t *structs.Condition) {
 		t.Resource = &x
 	}
 	t.LastTransitionTime = timeToStructs(s.LastTransitionTime)
+	t.Type = s.Type
+	t.ObservedGeneration = s.ObservedGeneration
+	t.TransitionCount = uint(s.TransitionCount)
+	t.Details = s.Details
+	t.ControlledBy = s.ControlledBy
 }
 func ConditionFromStructs(t *structs.Condition, s *Condition) {
 	if s == nil {
 		return
 	}
-	s.Status = t.Status
+	s.Status = string(t.Status)
 	s.Reason = t.Reason
 	s.Message = t.Message
 	if t.Resource != nil {
@@ -207,6 +212,11 @@ func ConditionFromStructs(t *structs.Condition, s *Condition) {
 		s.Resource = &x
 	}
 	s.LastTransitionTime = timeFromStructs(t.LastTransitionTime)
+	s.Type = t.Type
+	s.ObservedGeneration = t.ObservedGeneration
+	s.TransitionCount = uint32(t.TransitionCount)
+	s.Details = t.Details
+	s.ControlledBy = t.ControlledBy
 }
 func CookieConfigToStructs(s *CookieConfig, t *structs.CookieConfig) {
 	if s == nil {
@@ -0,0 +1,37 @@
+package pbconfigentry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestStatus_RoundTrip(t *testing.T) {
+	now := time.Now().UTC()
+	original := structs.Status{
+		Conditions: []structs.Condition{
+			{
+				Type:               "Accepted",
+				Status:             structs.ConditionStatusFalse,
+				Reason:             "ListenersNotValid",
+				Message:            "no valid listeners",
+				Resource:           &structs.ResourceReference{Kind: structs.APIGateway, Name: "gateway", SectionName: "http"},
+				LastTransitionTime: &now,
+				ObservedGeneration: 12,
+				TransitionCount:    3,
+				Details:            map[string]string{"listener_port": "8080"},
+				ControlledBy:       "api-gateway-controller",
+			},
+		},
+	}
+
+	var status Status
+	StatusFromStructs(&original, &status)
+
+	var result structs.Status
+	StatusToStructs(&status, &result)
+	require.Equal(t, original, result)
+}